@@ -0,0 +1,137 @@
+package minimessage
+
+import (
+	"math"
+
+	"github.com/lucasb-eyer/go-colorful"
+	"go.minekube.com/common/minecraft/color"
+	c "go.minekube.com/common/minecraft/component"
+)
+
+// GradientColorSpace selects which color space Gradient interpolates in.
+type GradientColorSpace int
+
+const (
+	// GradientRGB lerps each of R, G and B independently. This is the
+	// original, cheapest behavior; it tends to produce a dull, grayish
+	// midpoint between saturated colors.
+	GradientRGB GradientColorSpace = iota
+	// GradientHSL lerps hue (along the shorter arc), saturation and
+	// lightness. This is the default used by the <gradient> tag, since it
+	// keeps intermediate colors saturated and matches how MiniMessage's
+	// reference implementation renders multi-stop gradients.
+	GradientHSL
+	// GradientOKLab lerps in the perceptually uniform OKLab space.
+	GradientOKLab
+)
+
+// GradientOptions configures Gradient.
+type GradientOptions struct {
+	Space GradientColorSpace
+}
+
+// Gradient renders content with a color interpolated across colors per
+// character, returning a `c.Text` whose children are one rune each (rune
+// count, not byte length, so multi-byte characters aren't split). colors
+// must have at least one entry.
+func Gradient(content string, style c.Style, opts GradientOptions, colors ...color.RGB) *c.Text {
+	runes := []rune(content)
+	var children []c.Component
+	for i, r := range runes {
+		t := 0.0
+		if len(runes) > 1 {
+			t = float64(i) / float64(len(runes)-1)
+		}
+		style.Color = hexColor(interpolateGradient(t, opts.Space, colors))
+		children = append(children, &c.Text{Content: string(r), S: style})
+	}
+	return &c.Text{Extra: children}
+}
+
+// Rainbow renders content with a full-saturation hue that sweeps across its
+// characters, offset by phase (a fraction of a full turn - 0.5 starts half
+// way round the wheel), as used by <rainbow> and <rainbow:phase>.
+func Rainbow(content string, style c.Style, phase float64) *c.Text {
+	runes := []rune(content)
+	var children []c.Component
+	for i, r := range runes {
+		t := 0.0
+		if len(runes) > 1 {
+			t = float64(i) / float64(len(runes)-1)
+		}
+		hue := math.Mod((t+phase)*360, 360)
+		if hue < 0 {
+			hue += 360
+		}
+		col := colorful.Hsv(hue, 1, 1)
+		style.Color = hexColor(&col)
+		children = append(children, &c.Text{Content: string(r), S: style})
+	}
+	return &c.Text{Extra: children}
+}
+
+// hexColor round-trips an RGB stop through its hex string, matching how the
+// rest of the package turns a color.RGB into a color.Color usable in a Style.
+func hexColor(rgb *colorful.Color) color.Color {
+	hex, _ := color.Hex(rgb.Hex())
+	return hex
+}
+
+// interpolateGradient picks the two stops surrounding t (0..1 across the
+// whole gradient) and interpolates between them in the requested space.
+func interpolateGradient(t float64, space GradientColorSpace, colors []color.RGB) *colorful.Color {
+	t = math.Min(math.Max(t, 0), 1)
+
+	if t == 1 || len(colors) == 1 {
+		last := colorful.Color(colors[len(colors)-1])
+		return &last
+	}
+
+	colorT := t * float64(len(colors)-1)
+	idx := int(math.Floor(colorT))
+	frac := colorT - float64(idx)
+	from := colorful.Color(colors[idx])
+	to := colorful.Color(colors[idx+1])
+
+	switch space {
+	case GradientHSL:
+		return lerpHSL(from, to, frac)
+	case GradientOKLab:
+		return lerpOKLab(from, to, frac)
+	default:
+		return lerpRGB(from, to, frac)
+	}
+}
+
+func lerpRGB(from, to colorful.Color, t float64) *colorful.Color {
+	return &colorful.Color{
+		R: lerp(t, from.R, to.R),
+		G: lerp(t, from.G, to.G),
+		B: lerp(t, from.B, to.B),
+	}
+}
+
+func lerpHSL(from, to colorful.Color, t float64) *colorful.Color {
+	h1, s1, l1 := from.Hsl()
+	h2, s2, l2 := to.Hsl()
+	result := colorful.Hsl(lerpHue(h1, h2, t), lerp(t, s1, s2), lerp(t, l1, l2))
+	return &result
+}
+
+func lerpOKLab(from, to colorful.Color, t float64) *colorful.Color {
+	l1, a1, b1 := from.OkLab()
+	l2, a2, b2 := to.OkLab()
+	result := colorful.OkLab(lerp(t, l1, l2), lerp(t, a1, a2), lerp(t, b1, b2))
+	return &result
+}
+
+// lerpHue interpolates an angle in degrees from a to b along whichever
+// direction is shorter, wrapping at 360.
+func lerpHue(a, b, t float64) float64 {
+	delta := math.Mod(b-a+540, 360) - 180
+	return math.Mod(a+delta*t+360, 360)
+}
+
+func lerp(t, from, to float64) float64 {
+	return from + t*(to-from)
+}
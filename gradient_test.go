@@ -0,0 +1,70 @@
+package minimessage
+
+import (
+	"strings"
+	"testing"
+
+	"go.minekube.com/common/minecraft/color"
+	c "go.minekube.com/common/minecraft/component"
+)
+
+func TestParseGradientRejectsZeroStops(t *testing.T) {
+	_, err := parse("<gradient>hello</gradient>")
+	if err == nil {
+		t.Fatal("expected an error for <gradient> with no color stops, got nil")
+	}
+	if !strings.Contains(err.Error(), "at least one color") {
+		t.Errorf("error = %q, want it to mention requiring at least one color", err.Error())
+	}
+}
+
+func TestParseGradientOneStopIsAFlatColor(t *testing.T) {
+	text, err := parse("<gradient:red>hi</gradient>")
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if len(text.Extra) != 1 {
+		t.Fatalf("got %d top-level components, want 1", len(text.Extra))
+	}
+	for _, r := range text.Extra[0].Children() {
+		if r.Style().Color == nil || r.Style().Color.Hex() != color.Red.Hex() {
+			t.Errorf("rune colored %v, want flat %s", r.Style().Color, color.Red.Hex())
+		}
+	}
+}
+
+func TestInterpolateGradientBoundaries(t *testing.T) {
+	stops := []color.RGB{*color.Red.RGB, *color.Blue.RGB}
+
+	start := interpolateGradient(0, GradientRGB, stops)
+	if hexColor(start).Hex() != color.Red.Hex() {
+		t.Errorf("t=0 = %s, want %s", hexColor(start).Hex(), color.Red.Hex())
+	}
+
+	end := interpolateGradient(1, GradientRGB, stops)
+	if hexColor(end).Hex() != color.Blue.Hex() {
+		t.Errorf("t=1 = %s, want %s", hexColor(end).Hex(), color.Blue.Hex())
+	}
+}
+
+func TestInterpolateGradientSingleStop(t *testing.T) {
+	stops := []color.RGB{*color.Green.RGB}
+	for _, tt := range []float64{0, 0.5, 1} {
+		got := interpolateGradient(tt, GradientHSL, stops)
+		if hexColor(got).Hex() != color.Green.Hex() {
+			t.Errorf("t=%v with a single stop = %s, want flat %s", tt, hexColor(got).Hex(), color.Green.Hex())
+		}
+	}
+}
+
+func TestRainbowProducesOneColorPerRune(t *testing.T) {
+	text := Rainbow("abc", c.Style{}, 0)
+	if len(text.Extra) != 3 {
+		t.Fatalf("got %d children, want 3 (one per rune)", len(text.Extra))
+	}
+	for i, r := range text.Extra {
+		if r.Style().Color == nil {
+			t.Errorf("rune %d has no color", i)
+		}
+	}
+}
@@ -10,7 +10,8 @@ package minimessage
 
 import (
 	"fmt"
-	"math"
+	"net/url"
+	"slices"
 	"strconv"
 	"strings"
 
@@ -21,96 +22,469 @@ import (
 	"go.minekube.com/common/minecraft/nbt"
 )
 
-// Parse takes a string as input and returns a `c.Text` object. It splits the input string by "<",
-// then further splits each substring by ">". It modifies the style based on the key (the part before ">")
-// and appends a new text component with the modified style and content (the part after ">").
+// ParseError describes a failure to parse a MiniMessage string. Pos is the rune
+// offset into the input at which the problem was detected.
+type ParseError struct {
+	Msg string
+	Pos int
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("minimessage: %s (at position %d)", e.Msg, e.Pos)
+}
+
+// MustParse is like Parse but panics if mini cannot be parsed.
+func MustParse(mini string) *c.Text {
+	text, err := parse(mini)
+	if err != nil {
+		panic(err)
+	}
+	return text
+}
+
+// Parse takes a MiniMessage formatted string and returns the parsed `c.Text` tree.
+// It tokenizes the input into open-tag, close-tag and text events (supporting
+// explicit `</tag>` closers, the generic `</>` closer, quoted tag arguments and
+// backslash escapes) and feeds that stream into a stack-based style builder, so
+// nested tags render correctly instead of being flattened. Any tag that fails
+// to apply - an unrecognized name, a malformed argument, an unclosed or
+// mismatched tag - is recovered per tag rather than failing the whole parse,
+// so one broken tag falls back to literal text instead of undoing the
+// formatting around it. Use MustParse if you want parse errors surfaced
+// instead of recovered from.
 func Parse(mini string) *c.Text {
-	var styles []c.Style
-	styles = append(styles, c.Style{Color: color.White})
+	// parseLenient only fails for errors outside any tag (none exist today,
+	// but run isn't guaranteed to always succeed), in which case there's
+	// nothing left to recover into but the raw input.
+	text, err := parseLenient(mini)
+	if err != nil {
+		return &c.Text{Content: mini}
+	}
+	return text
+}
 
-	var components []c.Component
+// parser walks a MiniMessage string rune by rune, maintaining a stack of open
+// tag names so that closing tags can be matched against the tag that opened
+// them (or, for `</>`, against whatever tag is innermost). A nil registry
+// means "use the fixed built-in tag switch", which is what Parse does to
+// stay backwards compatible; ParseWith supplies a real registry. lenient
+// makes an unrecognized tag fall back to literal text instead of aborting;
+// only Parse turns it on.
+type parser struct {
+	input    []rune
+	pos      int
+	stack    []string
+	registry *Registry
+	lenient  bool
+}
 
-	for _, s := range strings.Split(mini, "<") {
-		if s == "" {
-			continue
+func parse(mini string) (*c.Text, error) {
+	return (&parser{input: []rune(mini)}).run()
+}
+
+// parseLenient is parse but with an unrecognized tag treated as literal text
+// instead of failing the parse; see Parse.
+func parseLenient(mini string) (*c.Text, error) {
+	return (&parser{input: []rune(mini), lenient: true}).run()
+}
+
+func (p *parser) run() (*c.Text, error) {
+	baseStyle := c.Style{Color: color.White}
+	comps, err := p.parseSpan("", baseStyle)
+	if err != nil {
+		return nil, err
+	}
+	return &c.Text{Extra: comps}, nil
+}
+
+// rawTag is a single `<...>` token as read off the input, before it has been
+// interpreted by applyTag. raw is the token's original source, `<` through
+// `>` inclusive, kept so a lenient parse can fall back to it verbatim.
+type rawTag struct {
+	closing bool
+	name    string
+	args    []string
+	pos     int
+	raw     string
+}
+
+// parseSpan parses text and tags until it encounters the closing tag for
+// openName (or, at the top level, until the input is exhausted), returning
+// the resulting components. style is the style inherited from the enclosing
+// tag.
+func (p *parser) parseSpan(openName string, style c.Style) ([]c.Component, error) {
+	var comps []c.Component
+	var text strings.Builder
+
+	flush := func() {
+		if text.Len() > 0 {
+			comps = append(comps, &c.Text{Content: text.String(), S: style})
+			text.Reset()
 		}
+	}
 
-		split := strings.Split(s, ">")
+	for p.pos < len(p.input) {
+		switch ch := p.input[p.pos]; {
+		case ch == '\\':
+			if lit, ok := p.readEscape(); ok {
+				text.WriteRune(lit)
+				continue
+			}
+			text.WriteRune(ch)
+			p.pos++
+
+		case ch == '<':
+			start := p.pos
+			tag, err := p.readTag()
+			if err != nil {
+				if p.lenient {
+					// No closing `>` (or closing quote) was ever found, so
+					// there's no well-formed raw token to fall back to -
+					// the rest of the input is the broken tag.
+					text.WriteString(string(p.input[start:]))
+					p.pos = len(p.input)
+					continue
+				}
+				return nil, err
+			}
+
+			if tag.closing {
+				if openName != "" && (tag.name == "" || tag.name == openName) {
+					flush()
+					return comps, nil
+				}
+				if slices.Contains(p.stack, tag.name) {
+					// Closes an ancestor, not us: rewind so our caller sees
+					// this same tag and can match it against its own
+					// openName, auto-closing us the same way EOF does
+					// rather than discarding everything we've parsed so far.
+					if p.lenient {
+						p.pos = start
+						flush()
+						return comps, nil
+					}
+					return nil, &ParseError{Msg: fmt.Sprintf("closing tag </%s> does not match open tag <%s>", tag.name, openName), Pos: start}
+				}
+				if p.lenient {
+					text.WriteString(tag.raw)
+					continue
+				}
+				return nil, &ParseError{Msg: fmt.Sprintf("closing tag </%s> does not match open tag <%s>", tag.name, openName), Pos: start}
+			}
 
-		key := split[0]
-		if strings.HasPrefix(key, "/") {
-			styles = styles[:len(styles)-1]
-		} else {
-			newStyle := styles[len(styles)-1]
+			flush()
+			child, err := p.openTag(tag, style)
+			if err != nil {
+				if p.lenient {
+					comps = append(comps, &c.Text{Content: tag.raw, S: style})
+					continue
+				}
+				return nil, err
+			}
+			if child != nil {
+				comps = append(comps, child)
+			}
 
-			styles = append(styles, newStyle)
+		default:
+			text.WriteRune(ch)
+			p.pos++
 		}
+	}
 
-		newText := modify(key, split[1], &styles[len(styles)-1])
-		components = append(components, newText)
+	flush()
+	if openName != "" && !p.lenient {
+		return nil, &ParseError{Msg: fmt.Sprintf("missing closing tag for <%s>", openName), Pos: len(p.input)}
+	}
+	return comps, nil
+}
+
+// openTag applies tag to a copy of style and parses its body (everything up
+// to its matching closing tag) as a nested span.
+func (p *parser) openTag(tag *rawTag, style c.Style) (c.Component, error) {
+	switch tag.name {
+	case "gradient":
+		if len(tag.args) < 1 {
+			return nil, &ParseError{Msg: "<gradient> requires at least one color", Pos: tag.pos}
+		}
+		content, err := p.readVerbatimUntilClose(tag.name)
+		if err != nil {
+			return nil, err
+		}
+		colors, err := parseGradientColors(tag.args)
+		if err != nil {
+			if p.lenient {
+				return literalBody(tag, content, style), nil
+			}
+			return nil, err
+		}
+		return gradient(content, style, colors...), nil
+
+	case "rainbow":
+		content, err := p.readVerbatimUntilClose(tag.name)
+		if err != nil {
+			return nil, err
+		}
+		phase := 0.0
+		if len(tag.args) > 0 {
+			parsed, err := strconv.ParseFloat(tag.args[0], 64)
+			if err != nil {
+				if p.lenient {
+					return literalBody(tag, content, style), nil
+				}
+				return nil, &ParseError{Msg: fmt.Sprintf("invalid <rainbow> phase %q", tag.args[0]), Pos: tag.pos}
+			}
+			phase = parsed
+		}
+		return Rainbow(content, style, phase), nil
 
+	case "syntax":
+		if len(tag.args) < 1 {
+			return nil, &ParseError{Msg: "<syntax> requires a language", Pos: tag.pos}
+		}
+		content, err := p.readVerbatimUntilClose(tag.name)
+		if err != nil {
+			return nil, err
+		}
+		styleName := ""
+		if len(tag.args) > 1 {
+			styleName = tag.args[1]
+		}
+		highlighted, err := HighlightSyntax(tag.args[0], content, style, styleName)
+		if err != nil {
+			if p.lenient {
+				return literalBody(tag, content, style), nil
+			}
+			return nil, &ParseError{Msg: err.Error(), Pos: tag.pos}
+		}
+		return highlighted, nil
+	}
+
+	if p.registry != nil {
+		replacement, err := resolveViaRegistry(p.registry, tag, &style)
+		if err != nil {
+			return nil, err
+		}
+		if replacement != nil {
+			return replacement, nil
+		}
+	} else if err := applyTag(tag, &style); err != nil {
+		return nil, err
 	}
 
-	return &c.Text{
-		Extra: components,
+	p.stack = append(p.stack, tag.name)
+	children, err := p.parseSpan(tag.name, style)
+	p.stack = p.stack[:len(p.stack)-1]
+	if err != nil {
+		return nil, err
 	}
+
+	return &c.Text{S: style, Extra: children}, nil
 }
 
-// modify takes a key, content, and style as input and returns a `c.Text` object. It modifies the style
-// based on the key and returns a new text component with the modified style and content.
-func modify(key string, content string, style *c.Style) *c.Text {
-	newText := &c.Text{}
+// readEscape interprets a backslash escape at p.pos (which must point at the
+// backslash) for the literal characters `<`, `>` and `\`. It reports ok=false
+// (leaving p.pos untouched) for anything else, so the backslash is kept as-is.
+func (p *parser) readEscape() (rune, bool) {
+	if p.pos+1 >= len(p.input) {
+		return 0, false
+	}
+	switch next := p.input[p.pos+1]; next {
+	case '<', '>', '\\':
+		p.pos += 2
+		return next, true
+	default:
+		return 0, false
+	}
+}
 
+// readTag reads a single `<...>` token starting at p.pos, honoring quoted
+// argument values so that `>` (and `:`) inside quotes don't end the tag early.
+func (p *parser) readTag() (*rawTag, error) {
+	start := p.pos
+	p.pos++ // skip '<'
+
+	var body strings.Builder
+	for p.pos < len(p.input) {
+		ch := p.input[p.pos]
+		if ch == '\'' || ch == '"' {
+			quote := ch
+			body.WriteRune(ch)
+			p.pos++
+			for p.pos < len(p.input) && p.input[p.pos] != quote {
+				if p.input[p.pos] == '\\' && p.pos+1 < len(p.input) {
+					body.WriteRune(p.input[p.pos])
+					p.pos++
+				}
+				body.WriteRune(p.input[p.pos])
+				p.pos++
+			}
+			if p.pos >= len(p.input) {
+				return nil, &ParseError{Msg: "unterminated quote in tag", Pos: start}
+			}
+			body.WriteRune(p.input[p.pos]) // closing quote
+			p.pos++
+			continue
+		}
+		if ch == '>' {
+			p.pos++
+			tag := newRawTag(body.String(), start)
+			tag.raw = string(p.input[start:p.pos])
+			return tag, nil
+		}
+		body.WriteRune(ch)
+		p.pos++
+	}
+	return nil, &ParseError{Msg: "unterminated tag", Pos: start}
+}
+
+// literalBody recovers a verbatim-content tag (<gradient>, <rainbow>,
+// <syntax>) whose body parsed fine but whose own arguments didn't, rebuilding
+// its literal source - tag.raw plus the already-consumed content plus a
+// synthesized closer - so that content isn't silently dropped.
+func literalBody(tag *rawTag, content string, style c.Style) *c.Text {
+	return &c.Text{Content: tag.raw + content + "</" + tag.name + ">", S: style}
+}
+
+// readVerbatimUntilClose reads raw text up to (but not including) the tag's
+// matching closing tag (`</name>` or `</>`), without interpreting any other
+// tags found along the way - they're kept as literal text. This is used by
+// tags such as <gradient> whose content is rendered character by character
+// rather than parsed as nested components.
+func (p *parser) readVerbatimUntilClose(name string) (string, error) {
+	var buf strings.Builder
+	for p.pos < len(p.input) {
+		ch := p.input[p.pos]
+		if ch == '\\' {
+			if lit, ok := p.readEscape(); ok {
+				buf.WriteRune(lit)
+				continue
+			}
+			buf.WriteRune(ch)
+			p.pos++
+			continue
+		}
+		if ch == '<' {
+			save := p.pos
+			tag, err := p.readTag()
+			if err != nil {
+				if p.lenient {
+					buf.WriteString(string(p.input[save:]))
+					p.pos = len(p.input)
+					break
+				}
+				return "", err
+			}
+			if tag.closing && (tag.name == "" || tag.name == name) {
+				return buf.String(), nil
+			}
+			buf.WriteString(string(p.input[save:p.pos]))
+			continue
+		}
+		buf.WriteRune(ch)
+		p.pos++
+	}
+	if p.lenient {
+		// Reached EOF without finding <name>'s closer; treat whatever was
+		// read so far as its whole (unclosed) content instead of discarding
+		// it.
+		return buf.String(), nil
+	}
+	return "", &ParseError{Msg: fmt.Sprintf("missing closing tag for <%s>", name), Pos: len(p.input)}
+}
+
+// newRawTag interprets the body of a `<...>` token (without the angle
+// brackets) as either a closing tag or an open tag with colon-separated,
+// quote-aware arguments.
+func newRawTag(body string, pos int) *rawTag {
+	if strings.HasPrefix(body, "/") {
+		return &rawTag{closing: true, name: strings.TrimSpace(body[1:]), pos: pos}
+	}
+
+	parts := splitTagParts(body)
+	return &rawTag{name: parts[0], args: parts[1:], pos: pos}
+}
+
+// splitTagParts splits a tag body on ':', treating single- or double-quoted
+// runs as a single part (with `\'`, `\"` and `\\` unescaped inside them) so
+// that argument values may themselves contain ':', '<' and '>'.
+func splitTagParts(body string) []string {
+	var parts []string
+	var cur strings.Builder
+	var quote rune
+
+	runes := []rune(body)
+	for i := 0; i < len(runes); i++ {
+		ch := runes[i]
+		switch {
+		case quote != 0:
+			if ch == '\\' && i+1 < len(runes) {
+				cur.WriteRune(runes[i+1])
+				i++
+				continue
+			}
+			if ch == quote {
+				quote = 0
+				continue
+			}
+			cur.WriteRune(ch)
+		case ch == '\'' || ch == '"':
+			quote = ch
+		case ch == ':':
+			parts = append(parts, cur.String())
+			cur.Reset()
+		default:
+			cur.WriteRune(ch)
+		}
+	}
+	parts = append(parts, cur.String())
+	return parts
+}
+
+// applyTag mutates style in place according to tag. It handles every built-in
+// tag except <gradient>, <rainbow> and <syntax>, which need access to their raw
+// content and are handled by openTag instead.
+func applyTag(tag *rawTag, style *c.Style) error {
 	switch {
-	case strings.HasPrefix(key, "#"): // <#ff00ff>
-		parsed, err := parseColor(key)
+	case strings.HasPrefix(tag.name, "#"): // <#ff00ff>
+		parsed, err := parseColor(tag.name)
 		if err != nil {
-			fmt.Println(err)
-			return nil
+			return err
 		}
 		style.Color = parsed
-		newText.Content = content
-		newText.S = *style
-	case strings.HasPrefix(key, "color"): // <color:light_purple>
-		colorName := strings.Split(key, ":")[1]
-		parsed, err := parseColor(colorName)
+
+	case tag.name == "color" || tag.name == "c": // <color:light_purple>
+		if len(tag.args) < 1 {
+			return &ParseError{Msg: "<color> requires a value", Pos: tag.pos}
+		}
+		parsed, err := parseColor(tag.args[0])
 		if err != nil {
-			fmt.Println(err)
-			return nil
+			return err
 		}
 		style.Color = parsed
-		newText.Content = content
-		newText.S = *style
 
-	case key == "bold" || key == "b": // <bold>
+	case tag.name == "bold" || tag.name == "b": // <bold>
 		style.Bold = c.True
-		newText.Content = content
-		newText.S = *style
 
-	case key == "italic" || key == "em" || key == "i": // <italic>
+	case tag.name == "italic" || tag.name == "em" || tag.name == "i": // <italic>
 		style.Italic = c.True
-		newText.Content = content
-		newText.S = *style
 
-	case key == "underlined" || key == "u": // <underlined>
+	case tag.name == "underlined" || tag.name == "u": // <underlined>
 		style.Underlined = c.True
-		newText.Content = content
-		newText.S = *style
 
-	case key == "strikethrough" || key == "st": // <strikethrough>
+	case tag.name == "strikethrough" || tag.name == "st": // <strikethrough>
 		style.Strikethrough = c.True
-		newText.Content = content
-		newText.S = *style
 
-	case key == "obfuscated" || key == "obf": // <obfuscated>
+	case tag.name == "obfuscated" || tag.name == "obf": // <obfuscated>
 		style.Obfuscated = c.True
-		newText.Content = content
-		newText.S = *style
 
-	case strings.HasPrefix(key, "click"): // <click:run_command:/seed>
-		clickKey := strings.Split(key, ":")
-		clickAction := clickKey[1]
-		clickValue := clickKey[2]
+	case tag.name == "click": // <click:run_command:/seed>
+		if len(tag.args) < 2 {
+			return &ParseError{Msg: "<click> requires an action and a value", Pos: tag.pos}
+		}
+		// args were split quote-aware by splitTagParts, so a quoted value
+		// (e.g. <click:open_url:'https://example.com/a:b'>) keeps its own
+		// ':' rather than being cut at it.
+		clickAction, clickValue := tag.args[0], tag.args[1]
 		switch clickAction {
 		case "change_page":
 			style.ClickEvent = c.ChangePage(clickValue)
@@ -119,35 +493,53 @@ func modify(key string, content string, style *c.Style) *c.Text {
 		case "open_file":
 			style.ClickEvent = c.OpenFile(clickValue)
 		case "open_url":
+			if _, err := url.ParseRequestURI(clickValue); err != nil {
+				return &ParseError{Msg: fmt.Sprintf("<click:open_url> value %q is not a valid URL: %s", clickValue, err), Pos: tag.pos}
+			}
 			style.ClickEvent = c.OpenUrl(clickValue)
 		case "run_command":
+			if !strings.HasPrefix(clickValue, "/") {
+				return &ParseError{Msg: fmt.Sprintf("<click:run_command> value %q must start with '/'", clickValue), Pos: tag.pos}
+			}
 			style.ClickEvent = c.RunCommand(clickValue)
 		case "suggest_command":
+			// Unlike run_command, suggest_command only ever populates the
+			// chat input box, so its value doesn't need to be a command.
 			style.ClickEvent = c.SuggestCommand(clickValue)
+		default:
+			return &ParseError{Msg: fmt.Sprintf("unknown click action %q", clickAction), Pos: tag.pos}
 		}
-		newText.Content = content
-		newText.S = *style
 
-	case strings.HasPrefix(key, "hover"): // <hover:show_text:test>
-		hoverKey := strings.Split(key, ":")
-		hoverAction := hoverKey[1]
-		hoverValue := hoverKey[2]
+	case tag.name == "hover": // <hover:show_text:test>
+		if len(tag.args) < 2 {
+			return &ParseError{Msg: "<hover> requires an action and a value", Pos: tag.pos}
+		}
+		hoverAction, hoverArgs := tag.args[0], tag.args[1:]
 		switch hoverAction {
 		case "show_text":
-			// TODO: parse using Parse()
-			style.HoverEvent = c.ShowText(&c.Text{
-				Content: hoverValue,
-			}) // _text_
+			text, err := parse(strings.Join(hoverArgs, ":"))
+			if err != nil {
+				return err
+			}
+			style.HoverEvent = c.ShowText(text) // _text_
 		case "show_item":
-			showItemKeys := strings.Split(hoverValue, ":")
-			itemType, _ := keyCommon.Parse(showItemKeys[0])
+			if len(hoverArgs) < 1 {
+				return &ParseError{Msg: "<hover:show_item> requires an item type", Pos: tag.pos}
+			}
+			itemType, err := keyCommon.Parse(hoverArgs[0])
+			if err != nil {
+				return &ParseError{Msg: fmt.Sprintf("<hover:show_item> type %q: %s", hoverArgs[0], err), Pos: tag.pos}
+			}
 			itemCount := 0 // not sure whats the default,
 			itemTag := nbt.NewBinaryTagHolder("")
-			if len(showItemKeys) >= 2 {
-				count, _ := strconv.Atoi(showItemKeys[1])
+			if len(hoverArgs) >= 2 {
+				count, err := strconv.Atoi(hoverArgs[1])
+				if err != nil {
+					return &ParseError{Msg: fmt.Sprintf("<hover:show_item> count %q: %s", hoverArgs[1], err), Pos: tag.pos}
+				}
 				itemCount = count
-				if len(showItemKeys) == 3 {
-					itemTag = nbt.NewBinaryTagHolder(showItemKeys[2])
+				if len(hoverArgs) == 3 {
+					itemTag = nbt.NewBinaryTagHolder(hoverArgs[2])
 				}
 			}
 			style.HoverEvent = c.ShowItem(&c.ShowItemHoverType{
@@ -156,41 +548,39 @@ func modify(key string, content string, style *c.Style) *c.Text {
 				NBT:   itemTag,
 			}) // _type_[:_count_[:tag]]
 		case "show_entity":
-			showEntityKeys := strings.Split(hoverValue, ":")
-			entityType, _ := keyCommon.Parse(showEntityKeys[0])
-			entityId, _ := uuid.Parse(showEntityKeys[1])
-			entityName := &c.Text{}
-			if len(showEntityKeys) == 3 {
-				entityName = Parse(showEntityKeys[2])
+			if len(hoverArgs) < 2 {
+				return &ParseError{Msg: "<hover:show_entity> requires a type and a uuid", Pos: tag.pos}
+			}
+			entityType, err := keyCommon.Parse(hoverArgs[0])
+			if err != nil {
+				return &ParseError{Msg: fmt.Sprintf("<hover:show_entity> type %q: %s", hoverArgs[0], err), Pos: tag.pos}
+			}
+			entityId, err := uuid.Parse(hoverArgs[1])
+			if err != nil {
+				return &ParseError{Msg: fmt.Sprintf("<hover:show_entity> uuid %q: %s", hoverArgs[1], err), Pos: tag.pos}
+			}
+			var entityName c.Component = &c.Text{}
+			if len(hoverArgs) == 3 {
+				name, err := parse(hoverArgs[2])
+				if err != nil {
+					return err
+				}
+				entityName = name
 			}
 			style.HoverEvent = c.ShowEntity(&c.ShowEntityHoverType{
 				Type: entityType,
 				Id:   entityId,
 				Name: entityName,
 			}) // _type_:_uuid_[:_name_]
-		}
-		newText.Content = content
-		newText.S = *style
-
-	case strings.HasPrefix(key, "gradient"): // <gradient:light_purple:gold>
-		colorKey := strings.Split(key, ":")
-		colorNames := colorKey[1:]
-
-		colors := make([]color.RGB, len(colorNames))
-		for i, col := range colorNames {
-			parsedColor, err := parseColor(col)
-			if err != nil {
-				fmt.Println(err)
-				return nil
-			}
-			newColor, _ := color.Make(parsedColor)
-			colors[i] = *newColor
+		default:
+			return &ParseError{Msg: fmt.Sprintf("unknown hover action %q", hoverAction), Pos: tag.pos}
 		}
 
-		newText = gradient(content, *style, colors...)
+	default:
+		return &ParseError{Msg: fmt.Sprintf("unknown tag <%s>", tag.name), Pos: tag.pos}
 	}
 
-	return newText
+	return nil
 }
 
 // parseColor takes a string as input and returns a `color.Color` object. It checks if the input string
@@ -223,49 +613,28 @@ func fromName(name string) (color.Color, error) {
 	return nil, fmt.Errorf("unknown color name: %s", name)
 }
 
-// gradient takes a string, a style, and a variable number of colors as input and returns a `c.Text` object.
-// It creates a gradient effect by interpolating between the input colors based on their position in the input string.
-func gradient(content string, style c.Style, colors ...color.RGB) *c.Text {
-	var component []c.Component
-	for id, i := range strings.Split(content, "") {
-		t := float64(id) / float64(len(content))
-		hex, _ := color.Hex(lerpColor(t, colors...).Hex())
-
-		style.Color = hex
-		component = append(component, &c.Text{
-			Content: string(i),
-			S:       style,
-		})
-	}
-
-	return &c.Text{
-		Extra: component,
-	}
-}
-
-// lerpColor takes a float and a variable number of colors as input and returns a `color.Color` object.
-// It interpolates between the input colors based on the input float.
-func lerpColor(t float64, colors ...color.RGB) color.Color {
-	t = math.Min(t, 1)
-
-	if t == 1 {
-		return &colors[len(colors)-1]
-	}
-
-	colorT := t * float64(len(colors)-1)
-	newT := colorT - math.Floor(colorT)
-	lastColor := colors[int(colorT)]
-	nextColor := colors[int(colorT+1)]
-
-	return &color.RGB{
-		R: lerpInt(newT, nextColor.R, lastColor.R),
-		G: lerpInt(newT, nextColor.G, lastColor.G),
-		B: lerpInt(newT, nextColor.B, lastColor.B),
+// parseGradientColors resolves the colon-separated color names/hex codes of a
+// <gradient:...> tag into RGB stops.
+func parseGradientColors(names []string) ([]color.RGB, error) {
+	colors := make([]color.RGB, len(names))
+	for i, name := range names {
+		parsed, err := parseColor(name)
+		if err != nil {
+			return nil, err
+		}
+		rgb, ok := color.Make(parsed)
+		if !ok {
+			return nil, fmt.Errorf("color %q cannot be converted to RGB", name)
+		}
+		colors[i] = *rgb
 	}
+	return colors, nil
 }
 
-// lerpInt takes three floats as input and returns a float. It performs linear interpolation between the
-// second and third input floats based on the first input float.
-func lerpInt(t float64, a float64, b float64) float64 {
-	return a*t + b*(1-t)
+// gradient is the <gradient:...> tag's entry point into Gradient, using
+// HSL interpolation so multi-stop gradients stay saturated instead of
+// muddying through gray at their midpoints the way a per-channel RGB lerp
+// does.
+func gradient(content string, style c.Style, colors ...color.RGB) *c.Text {
+	return Gradient(content, style, GradientOptions{Space: GradientHSL}, colors...)
 }
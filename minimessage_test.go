@@ -0,0 +1,238 @@
+package minimessage
+
+import (
+	"strings"
+	"testing"
+
+	c "go.minekube.com/common/minecraft/component"
+)
+
+func TestParseNestedTags(t *testing.T) {
+	text, err := parse("Hello <bold><color:red>world</color></bold>!")
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+
+	if len(text.Extra) != 3 {
+		t.Fatalf("got %d top-level components, want 3 (%+v)", len(text.Extra), text.Extra)
+	}
+
+	bold := text.Extra[1].(*c.Text)
+	if bold.S.Bold != c.True {
+		t.Errorf("bold wrapper: Bold = %v, want True", bold.S.Bold)
+	}
+	if len(bold.Extra) != 1 {
+		t.Fatalf("bold wrapper has %d children, want 1", len(bold.Extra))
+	}
+
+	red := bold.Extra[0].(*c.Text)
+	if red.S.Bold != c.True {
+		t.Errorf("nested <color:red>: Bold = %v, want True (inherited)", red.S.Bold)
+	}
+	if red.S.Color == nil || red.S.Color.Hex() != "#ff5555" {
+		t.Errorf("nested <color:red>: Color = %v, want #ff5555", red.S.Color)
+	}
+	if len(red.Extra) != 1 || red.Extra[0].(*c.Text).Content != "world" {
+		t.Errorf("nested <color:red> content = %+v, want \"world\"", red.Extra)
+	}
+}
+
+func TestParseHoverInHover(t *testing.T) {
+	// A hover's show_text body is itself parsed as MiniMessage, so it can
+	// contain another hover tag.
+	mini := `<hover:show_text:'<hover:show_text:"inner"><bold>outer</bold></hover>'>text</hover>`
+	text, err := parse(mini)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+
+	if len(text.Extra) != 1 {
+		t.Fatalf("got %d top-level components, want 1", len(text.Extra))
+	}
+	outer := text.Extra[0].(*c.Text)
+	if outer.S.HoverEvent == nil {
+		t.Fatal("outer component has no HoverEvent")
+	}
+	outerHoverText, ok := outer.S.HoverEvent.Value().(c.Component)
+	if !ok {
+		t.Fatalf("outer HoverEvent value is %T, want c.Component", outer.S.HoverEvent.Value())
+	}
+
+	if len(outerHoverText.Children()) != 1 {
+		t.Fatalf("hover body has %d top-level components, want 1 (the nested <hover>)", len(outerHoverText.Children()))
+	}
+	innerHover := outerHoverText.Children()[0].(*c.Text)
+	if innerHover.S.HoverEvent == nil {
+		t.Fatal("nested <hover:show_text:\"inner\"> lost its own HoverEvent")
+	}
+
+	innerBold := innerHover.Children()[0].(*c.Text)
+	if innerBold.S.Bold != c.True {
+		t.Errorf("hover body's <bold>: Bold = %v, want True", innerBold.S.Bold)
+	}
+	if innerBold.S.HoverEvent == nil {
+		t.Fatal("hover body's <bold> should still carry the innermost hover's HoverEvent")
+	}
+}
+
+func TestParseUnknownTagRecovery(t *testing.T) {
+	text := Parse("Hello <bold>world</bold>, check my <nonexistenttag> out!")
+
+	if text.Content != "" {
+		t.Fatalf("Parse degraded to raw text %q, want recovered formatting", text.Content)
+	}
+
+	var bold *c.Text
+	for _, comp := range text.Extra {
+		if t, ok := comp.(*c.Text); ok && t.S.Bold == c.True {
+			bold = t
+		}
+	}
+	if bold == nil {
+		t.Fatal("<bold>world</bold> styling was lost even though it parses fine on its own")
+	}
+
+	var joined strings.Builder
+	writePlain(&joined, text)
+	if !strings.Contains(joined.String(), "<nonexistenttag>") {
+		t.Errorf("expected the unrecognized tag to survive as literal text, got %q", joined.String())
+	}
+	if !strings.Contains(joined.String(), "out!") {
+		t.Errorf("text after the unrecognized tag was dropped, got %q", joined.String())
+	}
+}
+
+func TestParseWithStaysStrictOnUnknownTag(t *testing.T) {
+	_, err := ParseWith("<nonexistenttag>", NewRegistry())
+	if err == nil {
+		t.Fatal("ParseWith should still report an error for an unknown tag, unlike Parse")
+	}
+}
+
+func TestParseRecoversUnclosedTag(t *testing.T) {
+	text := Parse("Hello <bold>world, unclosed")
+	if text.Content != "" {
+		t.Fatalf("Parse degraded to raw text %q, want recovered formatting", text.Content)
+	}
+
+	var bold *c.Text
+	for _, comp := range text.Extra {
+		if t, ok := comp.(*c.Text); ok && t.S.Bold == c.True {
+			bold = t
+		}
+	}
+	if bold == nil {
+		t.Fatal("<bold> was dropped instead of being auto-closed at end of input")
+	}
+
+	var joined strings.Builder
+	writePlain(&joined, text)
+	if joined.String() != "Hello world, unclosed" {
+		t.Errorf("content = %q, want %q", joined.String(), "Hello world, unclosed")
+	}
+}
+
+func TestParseRecoversMismatchedCloser(t *testing.T) {
+	text := Parse("Hello <bold>world</italic> after")
+	if text.Content != "" {
+		t.Fatalf("Parse degraded to raw text %q, want recovered formatting", text.Content)
+	}
+
+	var bold *c.Text
+	for _, comp := range text.Extra {
+		if t, ok := comp.(*c.Text); ok && t.S.Bold == c.True {
+			bold = t
+		}
+	}
+	if bold == nil {
+		t.Fatal("<bold>...</italic>: <bold> styling was lost even though it parses fine on its own")
+	}
+
+	var joined strings.Builder
+	writePlain(&joined, text)
+	if !strings.Contains(joined.String(), "</italic>") {
+		t.Errorf("expected the stray </italic> to survive as literal text, got %q", joined.String())
+	}
+	if !strings.Contains(joined.String(), "after") {
+		t.Errorf("text after the stray closer was dropped, got %q", joined.String())
+	}
+}
+
+func TestParseRecoversGradientBadColorKeepsBody(t *testing.T) {
+	text := Parse("<gradient:notacolor>hello</gradient> world")
+	if text.Content != "" {
+		t.Fatalf("Parse degraded to raw text %q, want recovered formatting", text.Content)
+	}
+
+	var joined strings.Builder
+	writePlain(&joined, text)
+	if !strings.Contains(joined.String(), "hello") {
+		t.Errorf("<gradient> body was dropped instead of surviving as literal text, got %q", joined.String())
+	}
+	if !strings.Contains(joined.String(), "world") {
+		t.Errorf("text after </gradient> was dropped, got %q", joined.String())
+	}
+}
+
+func TestParseRecoversAncestorMismatchedCloserKeepsInnerTag(t *testing.T) {
+	text := Parse("<bold><italic>world</bold>after</italic>")
+	if text.Content != "" {
+		t.Fatalf("Parse degraded to raw text %q, want recovered formatting", text.Content)
+	}
+
+	bold := text.Extra[0].(*c.Text)
+	if bold.S.Bold != c.True {
+		t.Fatalf("<bold> styling was lost")
+	}
+	if len(bold.Extra) != 1 {
+		t.Fatalf("<bold> has %d children, want 1 (the nested <italic>)", len(bold.Extra))
+	}
+	italic := bold.Extra[0].(*c.Text)
+	if italic.S.Italic != c.True {
+		t.Fatalf("<italic> styling was lost")
+	}
+
+	var joined strings.Builder
+	writePlain(&joined, text)
+	if !strings.Contains(joined.String(), "world") {
+		t.Errorf("text inside the mismatched <italic> was dropped, got %q", joined.String())
+	}
+	if !strings.Contains(joined.String(), "after") {
+		t.Errorf("text after the stray </bold> was dropped, got %q", joined.String())
+	}
+}
+
+func TestParseRecoversMalformedBuiltinArgs(t *testing.T) {
+	text := Parse("<click:open_url:not a url>broken link</click> and more")
+	if text.Content != "" {
+		t.Fatalf("Parse degraded to raw text %q, want recovered formatting", text.Content)
+	}
+
+	var joined strings.Builder
+	writePlain(&joined, text)
+	if !strings.Contains(joined.String(), "<click:open_url:not a url>") {
+		t.Errorf("expected the malformed <click> tag to survive as literal text, got %q", joined.String())
+	}
+	if !strings.Contains(joined.String(), "and more") {
+		t.Errorf("text after the malformed tag was dropped, got %q", joined.String())
+	}
+}
+
+func TestApplyTagClickSuggestCommand(t *testing.T) {
+	var style c.Style
+	tag := &rawTag{name: "click", args: []string{"suggest_command", "hello there"}}
+	if err := applyTag(tag, &style); err != nil {
+		t.Fatalf("suggest_command with non-command text: %v", err)
+	}
+	if style.ClickEvent == nil || style.ClickEvent.Value() != "hello there" {
+		t.Errorf("ClickEvent = %+v, want SuggestCommand(\"hello there\")", style.ClickEvent)
+	}
+}
+
+func TestApplyTagClickRunCommandStillRequiresSlash(t *testing.T) {
+	var style c.Style
+	tag := &rawTag{name: "click", args: []string{"run_command", "not a command"}}
+	if err := applyTag(tag, &style); err == nil {
+		t.Fatal("run_command without a leading '/' should still be rejected")
+	}
+}
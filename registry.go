@@ -0,0 +1,130 @@
+package minimessage
+
+import (
+	"fmt"
+	"strings"
+
+	"go.minekube.com/common/minecraft/color"
+	c "go.minekube.com/common/minecraft/component"
+)
+
+// TagResolver resolves a single MiniMessage tag occurrence into a style
+// mutation and/or a replacement component. Implementations are invoked once
+// per matching open tag with the raw colon-separated arguments that followed
+// the tag name (e.g. for `<hover:show_text:hi>`, args is
+// `["show_text", "hi"]`).
+//
+// Resolve mutates style in place (e.g. setting a color or decoration); the
+// tag's body is then parsed with the mutated style and appended as the
+// resulting component's children. If Resolve instead returns a non-nil
+// replacement, that component is used verbatim and the tag is treated as
+// self-closing: no body is read for it, so what follows is parsed as part of
+// the enclosing content and a matching `</name>` closer is a stray tag -
+// useful for a tag that fully controls its own content, such as a Placeholder
+// substitution.
+type TagResolver interface {
+	Resolve(args []string, style *c.Style) (replacement c.Component, err error)
+}
+
+// TagResolverFunc adapts a function to a TagResolver.
+type TagResolverFunc func(args []string, style *c.Style) (c.Component, error)
+
+// Resolve calls f.
+func (f TagResolverFunc) Resolve(args []string, style *c.Style) (c.Component, error) {
+	return f(args, style)
+}
+
+// Registry is a set of named TagResolvers consulted while parsing. A
+// Registry built with NewRegistry already knows every style-mutating
+// built-in tag (color, bold, italic, underlined, strikethrough, obfuscated,
+// click, hover); callers Register additional tags such as `<player>` or
+// `<server>` on top of it. Content tags that need raw access to their body
+// rather than just arguments - `<gradient>`, for instance - are handled
+// directly by the parser and aren't expressed through this interface.
+type Registry struct {
+	resolvers map[string]TagResolver
+}
+
+// NewRegistry returns a Registry pre-populated with resolvers for the
+// style-mutating built-in tags.
+func NewRegistry() *Registry {
+	r := &Registry{resolvers: make(map[string]TagResolver)}
+	registerBuiltins(r)
+	return r
+}
+
+// Register adds or replaces the resolver used for name. Registering a name
+// that collides with a style-mutating built-in tag overrides the built-in;
+// <gradient>, <rainbow> and <syntax> are matched by the parser before the
+// registry is consulted, so registering those names has no effect.
+func (r *Registry) Register(name string, resolver TagResolver) {
+	r.resolvers[name] = resolver
+}
+
+// Placeholder registers a tag called name that is replaced at parse time by
+// replacement, regardless of the enclosing style. It's a convenience
+// wrapper around Register for the common case of substituting a fixed
+// component tree, e.g. registry.Placeholder("player", &c.Text{Content: name}).
+func (r *Registry) Placeholder(name string, replacement c.Component) {
+	r.Register(name, TagResolverFunc(func([]string, *c.Style) (c.Component, error) {
+		return replacement, nil
+	}))
+}
+
+func (r *Registry) lookup(name string) (TagResolver, bool) {
+	if r == nil {
+		return nil, false
+	}
+	res, ok := r.resolvers[name]
+	return res, ok
+}
+
+// registerBuiltins wires up a TagResolver for every style-mutating built-in
+// tag by delegating to applyTag, so the registry and the zero-registry fast
+// path in Parse share one implementation of each tag's semantics.
+func registerBuiltins(r *Registry) {
+	names := []string{
+		"color", "c",
+		"bold", "b",
+		"italic", "em", "i",
+		"underlined", "u",
+		"strikethrough", "st",
+		"obfuscated", "obf",
+		"click",
+		"hover",
+	}
+	for _, name := range names {
+		name := name
+		r.Register(name, TagResolverFunc(func(args []string, style *c.Style) (c.Component, error) {
+			return nil, applyTag(&rawTag{name: name, args: args}, style)
+		}))
+	}
+}
+
+// ParseWith is like Parse but dispatches every tag through registry instead
+// of the fixed built-in switch, so tags registered via registry.Register
+// (including placeholders added via registry.Placeholder) are recognized
+// alongside the built-ins. Unlike Parse, it returns a ParseError instead of
+// swallowing it.
+func ParseWith(mini string, registry *Registry) (*c.Text, error) {
+	p := &parser{input: []rune(mini), registry: registry}
+	baseStyle := c.Style{Color: color.White}
+	comps, err := p.parseSpan("", baseStyle)
+	if err != nil {
+		return nil, err
+	}
+	return &c.Text{Extra: comps}, nil
+}
+
+// resolveViaRegistry looks up tag.name in registry, falling back to the hex
+// color syntax (`<#rrggbb>`), and reports an error for anything else; unlike
+// Parse, there is no fallback that keeps an unknown tag as literal text.
+func resolveViaRegistry(registry *Registry, tag *rawTag, style *c.Style) (c.Component, error) {
+	if resolver, ok := registry.lookup(tag.name); ok {
+		return resolver.Resolve(tag.args, style)
+	}
+	if strings.HasPrefix(tag.name, "#") {
+		return nil, applyTag(tag, style)
+	}
+	return nil, &ParseError{Msg: fmt.Sprintf("unknown tag <%s>", tag.name), Pos: tag.pos}
+}
@@ -0,0 +1,184 @@
+package minimessage
+
+import (
+	"strconv"
+	"strings"
+
+	"go.minekube.com/common/minecraft/color"
+	c "go.minekube.com/common/minecraft/component"
+)
+
+// Serialize converts a component tree back into a MiniMessage string - the
+// inverse of Parse. It walks the tree depth-first, diffing each component's
+// style against its parent's (inherited) style and emitting only the
+// open/close tags needed to reproduce the difference, so round-tripping
+// Parse -> Serialize -> Parse reproduces the original styling.
+//
+// Decorations that are explicitly turned off (rather than simply not set)
+// cannot be expressed, since Parse has no tag for that; Serialize omits them
+// the same way it omits an unset decoration.
+func Serialize(comp c.Component) string {
+	var b strings.Builder
+	serialize(&b, comp, c.Style{Color: color.White})
+	return b.String()
+}
+
+// Strip removes every MiniMessage tag from mini and returns the remaining
+// literal text, mirroring gookit/color's StripExpr. If mini does not parse
+// (an unknown tag, a malformed argument, an unclosed tag), it is returned
+// unchanged, tags included.
+func Strip(mini string) string {
+	text, err := parse(mini)
+	if err != nil {
+		return mini
+	}
+	var b strings.Builder
+	writePlain(&b, text)
+	return b.String()
+}
+
+func writePlain(b *strings.Builder, comp c.Component) {
+	if t, ok := comp.(*c.Text); ok {
+		b.WriteString(t.Content)
+	}
+	for _, child := range comp.Children() {
+		writePlain(b, child)
+	}
+}
+
+func serialize(b *strings.Builder, comp c.Component, parent c.Style) {
+	style := *comp.Style()
+	if style.Color == nil {
+		// A component with no color of its own (notably the synthetic root
+		// `c.Text` Parse wraps its output in) still renders in whatever
+		// color its parent resolved to, so it must inherit that color for
+		// diffing and for its own children, rather than compare as if it
+		// had none.
+		style.Color = parent.Color
+	}
+	tags := styleTags(parent, style)
+
+	for _, tag := range tags {
+		b.WriteByte('<')
+		b.WriteString(tag.open)
+		b.WriteByte('>')
+	}
+
+	if t, ok := comp.(*c.Text); ok {
+		b.WriteString(escapeContent(t.Content))
+	}
+
+	for _, child := range comp.Children() {
+		serialize(b, child, style)
+	}
+
+	for i := len(tags) - 1; i >= 0; i-- {
+		b.WriteString("</")
+		b.WriteString(tags[i].name)
+		b.WriteByte('>')
+	}
+}
+
+// styleTag is one open tag emitted for a style difference, paired with the
+// tag name its closer must repeat.
+type styleTag struct {
+	open string
+	name string
+}
+
+// styleTags returns the tags needed to turn parent into style.
+func styleTags(parent, style c.Style) []styleTag {
+	var tags []styleTag
+
+	if style.Color != nil && !sameColor(parent.Color, style.Color) {
+		if named, ok := style.Color.(*color.Named); ok {
+			tags = append(tags, styleTag{open: "color:" + named.Name, name: "color"})
+		} else {
+			hex := style.Color.Hex()
+			tags = append(tags, styleTag{open: hex, name: hex})
+		}
+	}
+
+	for _, d := range []struct {
+		name  string
+		state func(c.Style) c.State
+	}{
+		{"bold", func(s c.Style) c.State { return s.Bold }},
+		{"italic", func(s c.Style) c.State { return s.Italic }},
+		{"underlined", func(s c.Style) c.State { return s.Underlined }},
+		{"strikethrough", func(s c.Style) c.State { return s.Strikethrough }},
+		{"obfuscated", func(s c.Style) c.State { return s.Obfuscated }},
+	} {
+		if d.state(style) == c.True && d.state(parent) != c.True {
+			tags = append(tags, styleTag{open: d.name, name: d.name})
+		}
+	}
+
+	if style.ClickEvent != nil && style.ClickEvent != parent.ClickEvent {
+		value := style.ClickEvent.Action().Name() + ":" + quoteArg(style.ClickEvent.Value())
+		tags = append(tags, styleTag{open: "click:" + value, name: "click"})
+	}
+
+	if style.HoverEvent != nil && style.HoverEvent != parent.HoverEvent {
+		tags = append(tags, styleTag{open: "hover:" + serializeHover(style.HoverEvent), name: "hover"})
+	}
+
+	return tags
+}
+
+func sameColor(a, b color.Color) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Hex() == b.Hex()
+}
+
+// serializeHover renders a HoverEvent's arguments the way applyTag expects
+// to read them back: show_text recursively serializes its component,
+// show_item and show_entity join their typed fields with ':'.
+func serializeHover(h c.HoverEvent) string {
+	switch h.Action().Name() {
+	case "show_text":
+		comp, _ := h.Value().(c.Component)
+		return "show_text:" + quoteArg(Serialize(comp))
+
+	case "show_item":
+		item, _ := h.Value().(*c.ShowItemHoverType)
+		parts := []string{item.Item.String()}
+		if item.Count != 0 || item.NBT != nil && item.NBT.String() != "" {
+			parts = append(parts, strconv.Itoa(item.Count))
+		}
+		if item.NBT != nil && item.NBT.String() != "" {
+			parts = append(parts, item.NBT.String())
+		}
+		return "show_item:" + strings.Join(parts, ":")
+
+	case "show_entity":
+		entity, _ := h.Value().(*c.ShowEntityHoverType)
+		parts := []string{entity.Type.String(), entity.Id.String()}
+		if entity.Name != nil {
+			parts = append(parts, quoteArg(Serialize(entity.Name)))
+		}
+		return "show_entity:" + strings.Join(parts, ":")
+
+	default:
+		return h.Action().Name()
+	}
+}
+
+// quoteArg wraps value in single quotes (escaping '\\' and any existing
+// quote) when it contains characters that would otherwise be misread as tag
+// syntax, so it survives being re-parsed as a single argument.
+func quoteArg(value string) string {
+	if !strings.ContainsAny(value, ":<>'\"") {
+		return value
+	}
+	escaped := strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(value)
+	return "'" + escaped + "'"
+}
+
+// escapeContent escapes the characters Parse treats specially so content can
+// be embedded back into a MiniMessage string unambiguously.
+func escapeContent(content string) string {
+	return strings.NewReplacer(`\`, `\\`, `<`, `\<`, `>`, `\>`).Replace(content)
+}
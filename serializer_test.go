@@ -0,0 +1,47 @@
+package minimessage
+
+import "testing"
+
+func TestSerializeNoSpuriousColor(t *testing.T) {
+	text, err := parse("plain text no tags")
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if got := Serialize(text); got != "plain text no tags" {
+		t.Errorf("Serialize(plain text) = %q, want no <color:white> wrapper", got)
+	}
+}
+
+func TestSerializeRoundTrip(t *testing.T) {
+	cases := []string{
+		"Hello <bold>world</bold>!",
+		"hello <bold><color:red>world</color></bold>!",
+		"<color:red>red</color> and <color:blue>blue</color>",
+	}
+	for _, mini := range cases {
+		text, err := parse(mini)
+		if err != nil {
+			t.Fatalf("parse(%q): %v", mini, err)
+		}
+		out := Serialize(text)
+		if out != mini {
+			t.Errorf("round-trip mismatch:\n  in:  %q\n  out: %q", mini, out)
+		}
+
+		// Re-parsing the serialized form should reproduce the same tree.
+		reparsed, err := parse(out)
+		if err != nil {
+			t.Fatalf("parse(Serialize(parse(%q))): %v", mini, err)
+		}
+		if Serialize(reparsed) != out {
+			t.Errorf("serialization isn't stable across a second round-trip for %q", mini)
+		}
+	}
+}
+
+func TestStripRemovesAllTags(t *testing.T) {
+	got := Strip("Hello <bold><color:red>world</color></bold>!")
+	if got != "Hello world!" {
+		t.Errorf("Strip = %q, want %q", got, "Hello world!")
+	}
+}
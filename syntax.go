@@ -0,0 +1,65 @@
+package minimessage
+
+import (
+	"fmt"
+
+	"github.com/alecthomas/chroma"
+	"github.com/alecthomas/chroma/lexers"
+	chromastyles "github.com/alecthomas/chroma/styles"
+	"go.minekube.com/common/minecraft/color"
+	c "go.minekube.com/common/minecraft/component"
+)
+
+// DefaultSyntaxStyle is the Chroma style <syntax:lang> falls back to when no
+// style name is given as a second argument.
+const DefaultSyntaxStyle = "monokai"
+
+// HighlightSyntax tokenizes code as lang using Chroma and returns a
+// component tree with one child per token, each starting from style (so an
+// enclosing <hover>, <click> or color isn't lost) and colored (and bolded/
+// italicized/underlined) according to styleName - an empty styleName falls
+// back to DefaultSyntaxStyle. Chroma's background colors are never applied:
+// chat has no concept of a per-run background, so leaking one in would just
+// look wrong.
+func HighlightSyntax(lang, code string, style c.Style, styleName string) (*c.Text, error) {
+	lexer := lexers.Get(lang)
+	if lexer == nil {
+		lexer = lexers.Fallback
+	}
+	lexer = chroma.Coalesce(lexer)
+
+	if styleName == "" {
+		styleName = DefaultSyntaxStyle
+	}
+	chromaStyle := chromastyles.Get(styleName)
+
+	iterator, err := lexer.Tokenise(nil, code)
+	if err != nil {
+		return nil, fmt.Errorf("minimessage: tokenising <syntax:%s>: %w", lang, err)
+	}
+
+	var children []c.Component
+	for _, tok := range iterator.Tokens() {
+		entry := chromaStyle.Get(tok.Type)
+
+		tokStyle := style
+		if entry.Colour.IsSet() {
+			hex, err := color.Hex(entry.Colour.String())
+			if err == nil {
+				tokStyle.Color = hex
+			}
+		}
+		if entry.Bold == chroma.Yes {
+			tokStyle.Bold = c.True
+		}
+		if entry.Italic == chroma.Yes {
+			tokStyle.Italic = c.True
+		}
+		if entry.Underline == chroma.Yes {
+			tokStyle.Underlined = c.True
+		}
+		children = append(children, &c.Text{Content: tok.Value, S: tokStyle})
+	}
+
+	return &c.Text{Extra: children}, nil
+}
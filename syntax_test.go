@@ -0,0 +1,58 @@
+package minimessage
+
+import (
+	"testing"
+
+	c "go.minekube.com/common/minecraft/component"
+)
+
+func TestHighlightSyntaxInheritsAmbientStyle(t *testing.T) {
+	style := c.Style{HoverEvent: c.ShowText(&c.Text{Content: "tip"})}
+	text, err := HighlightSyntax("go", "func main(){}", style, "")
+	if err != nil {
+		t.Fatalf("HighlightSyntax: %v", err)
+	}
+
+	if len(text.Extra) == 0 {
+		t.Fatal("got no tokens")
+	}
+	for i, tok := range text.Extra {
+		if tok.Style().HoverEvent == nil {
+			t.Errorf("token %d lost the ambient HoverEvent", i)
+		}
+	}
+}
+
+func TestHighlightSyntaxOverridesOnlyWhatChromaSpecifies(t *testing.T) {
+	style := c.Style{Bold: c.True}
+	text, err := HighlightSyntax("go", "x", style, "")
+	if err != nil {
+		t.Fatalf("HighlightSyntax: %v", err)
+	}
+	for i, tok := range text.Extra {
+		if tok.Style().Bold != c.True {
+			t.Errorf("token %d: Bold = %v, want inherited True", i, tok.Style().Bold)
+		}
+	}
+}
+
+func TestParseNestedSyntaxKeepsEnclosingHover(t *testing.T) {
+	text, err := parse(`<hover:show_text:'tip'><syntax:go>func main(){}</syntax></hover>`)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+
+	hover := text.Extra[0]
+	if hover.Style().HoverEvent == nil {
+		t.Fatal("<hover> wrapper lost its own HoverEvent")
+	}
+	tokens := hover.Children()[0].Children()
+	if len(tokens) == 0 {
+		t.Fatal("got no highlighted tokens")
+	}
+	for i, tok := range tokens {
+		if tok.Style().HoverEvent == nil {
+			t.Errorf("token %d: HoverEvent lost from the enclosing <hover>", i)
+		}
+	}
+}